@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// tokenFilePath resolves where cached OAuth tokens are read from and written
+// to. GOOGLE_AUTH_TOKEN_FILE takes precedence; otherwise we fall back to
+// ~/.gohex2ascii/token.json so repeated runs don't re-trigger the browser
+// flow.
+func tokenFilePath() (string, error) {
+	if path := os.Getenv("GOOGLE_AUTH_TOKEN_FILE"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine home directory: %v", err)
+	}
+
+	return filepath.Join(home, ".gohex2ascii", "token.json"), nil
+}
+
+func loadCachedToken(path string) (*oauth2.Token, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tok := &oauth2.Token{}
+	if err := json.NewDecoder(f).Decode(tok); err != nil {
+		return nil, err
+	}
+
+	return tok, nil
+}
+
+func saveCachedToken(path string, tok *oauth2.Token) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("unable to create token cache directory: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to cache oauth token: %v", err)
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(tok)
+}
+
+// requestTokenFromWeb walks the user through the installed-app flow: print
+// the consent URL, wait for the code to be pasted back on stdin, then
+// exchange it for a token.
+func requestTokenFromWeb(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	fmt.Printf("Go to the following link in your browser, then type the authorization code:\n%v\n", authURL)
+
+	fmt.Print("Authorization code: ")
+	reader := bufio.NewReader(os.Stdin)
+	code, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("unable to read authorization code: %v", err)
+	}
+	code = strings.TrimSpace(code)
+
+	tok, err := config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("unable to exchange authorization code for token: %v", err)
+	}
+
+	return tok, nil
+}
+
+// getOAuthClient returns an *http.Client authorized against the installed-app
+// OAuth config in credentialsPath, reusing a cached token on disk whenever
+// one can be read (an expired token is refreshed by the client through its
+// refresh token; it is not re-checked here). The client is scoped for both the Sheets and
+// Drive export backends so a single sign-in covers either one.
+func getOAuthClient(ctx context.Context, credentialsPath string) (*http.Client, error) {
+	b, err := ioutil.ReadFile(credentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client secret file: %v", err)
+	}
+
+	config, err := google.ConfigFromJSON(b, sheets.SpreadsheetsScope, drive.DriveScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse client secret file: %v", err)
+	}
+
+	cachePath, err := tokenFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := loadCachedToken(cachePath)
+	if err != nil {
+		tok, err = requestTokenFromWeb(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := saveCachedToken(cachePath, tok); err != nil {
+			return nil, err
+		}
+	}
+
+	return config.Client(ctx, tok), nil
+}
+
+// setupGoogleSheetsWithOAuth authenticates as the signed-in user rather than
+// an API key or service account, so spreadsheets it creates land in the
+// user's own Drive instead of requiring a manual share.
+func setupGoogleSheetsWithOAuth(ctx context.Context, credentialsPath string) (*GoogleConfig, error) {
+	client, err := getOAuthClient(ctx, credentialsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create sheets service: %v", err)
+	}
+
+	return &GoogleConfig{
+		sheetsService: srv,
+		useApiKey:     false,
+	}, nil
+}
+
+// setupDriveWithOAuth authenticates as the signed-in user for the Drive
+// export backend, reusing the same cached token as the Sheets OAuth path.
+func setupDriveWithOAuth(ctx context.Context, credentialsPath string) (*GoogleConfig, error) {
+	client, err := getOAuthClient(ctx, credentialsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := drive.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create drive service: %v", err)
+	}
+
+	return &GoogleConfig{
+		driveService: srv,
+		useApiKey:    false,
+	}, nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	flagSrc           string
+	flagDst           string
+	flagSpreadsheetID string
+	flagDriveFolderID string
+	flagCredentials   string
+	flagAPIKey        string
+	flagAuth          string
+	flagWorkers       int
+	flagFormat        string
+	flagDecoder       string
+	flagDelimiter     string
+	flagConfig        string
+	flagInteractive   bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:           "gohex2ascii",
+	Short:         "Convert hex and other encoded dumps to ASCII",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagInteractive {
+			return runInteractive(cmd.Context())
+		}
+		return cmd.Help()
+	},
+}
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Decode source files and write the ASCII output to a local folder",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagInteractive {
+			return runInteractive(cmd.Context())
+		}
+		return runConvert(cmd.Context())
+	},
+}
+
+var sheetsCmd = &cobra.Command{
+	Use:   "sheets",
+	Short: "Decode source files and export them to Google Sheets",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagInteractive {
+			return runInteractive(cmd.Context())
+		}
+		return runSheets(cmd.Context())
+	},
+}
+
+var driveCmd = &cobra.Command{
+	Use:   "drive",
+	Short: "Decode source files and upload them to Google Drive",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagInteractive {
+			return runInteractive(cmd.Context())
+		}
+		return runDrive(cmd.Context())
+	},
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage Google OAuth credentials",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Sign in with a Google account and cache the OAuth token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagCredentials == "" {
+			return fmt.Errorf("--credentials is required")
+		}
+		_, err := getOAuthClient(cmd.Context(), flagCredentials)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Signed in. Token cached for future runs.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&flagSrc, "src", "", "source folder containing files to convert")
+	rootCmd.PersistentFlags().StringVar(&flagDst, "dst", "", "destination folder for local conversion output")
+	rootCmd.PersistentFlags().StringVar(&flagSpreadsheetID, "spreadsheet-id", "", "existing Google Sheets spreadsheet ID (leave empty to create one)")
+	rootCmd.PersistentFlags().StringVar(&flagDriveFolderID, "drive-folder-id", "", "existing Google Drive folder ID (leave empty to create one)")
+	rootCmd.PersistentFlags().StringVar(&flagCredentials, "credentials", "", "path to a service-account JSON file or OAuth client_secret.json")
+	rootCmd.PersistentFlags().StringVar(&flagAPIKey, "api-key", "", "Google Sheets API key (Sheets export only, requires --spreadsheet-id)")
+	rootCmd.PersistentFlags().StringVar(&flagAuth, "auth", "service-account", "authentication method: api-key, service-account, or oauth")
+	rootCmd.PersistentFlags().IntVar(&flagWorkers, "workers", runtime.NumCPU(), "number of parallel workers")
+	rootCmd.PersistentFlags().StringVar(&flagFormat, "format", "", "override input decoder: hex, base64, binary, or intelhex (default: detected from extension)")
+	rootCmd.PersistentFlags().StringVar(&flagDecoder, "decoder", "", "alias for --format")
+	rootCmd.PersistentFlags().StringVar(&flagDelimiter, "delimiter", "\t", "column delimiter for Sheets export")
+	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "path to a gohex2ascii.yaml config file")
+	rootCmd.PersistentFlags().BoolVar(&flagInteractive, "interactive", false, "run the interactive wizard instead of using flags")
+
+	rootCmd.PersistentPreRunE = applyFileConfigAndEnv
+
+	authCmd.AddCommand(authLoginCmd)
+	rootCmd.AddCommand(convertCmd, sheetsCmd, driveCmd, authCmd)
+}
+
+// applyFileConfigAndEnv fills in any flag the user didn't pass on the
+// command line from --config (if set) and then from well-known environment
+// variables, in that order, so flags always win.
+func applyFileConfigAndEnv(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+
+	if flagConfig != "" {
+		cfg, err := loadFileConfig(flagConfig)
+		if err != nil {
+			return err
+		}
+
+		setIfUnchanged(flags, "src", &flagSrc, cfg.Src)
+		setIfUnchanged(flags, "dst", &flagDst, cfg.Dst)
+		setIfUnchanged(flags, "spreadsheet-id", &flagSpreadsheetID, cfg.SpreadsheetID)
+		setIfUnchanged(flags, "drive-folder-id", &flagDriveFolderID, cfg.DriveFolderID)
+		setIfUnchanged(flags, "credentials", &flagCredentials, cfg.Credentials)
+		setIfUnchanged(flags, "api-key", &flagAPIKey, cfg.APIKey)
+		setIfUnchanged(flags, "auth", &flagAuth, cfg.Auth)
+		if !flags.Changed("decoder") {
+			setIfUnchanged(flags, "format", &flagFormat, cfg.Format)
+		}
+		setIfUnchanged(flags, "delimiter", &flagDelimiter, cfg.Delimiter)
+		if cfg.Workers > 0 && !flags.Changed("workers") {
+			flagWorkers = cfg.Workers
+		}
+	}
+
+	if !flags.Changed("credentials") {
+		flagCredentials = envOrDefault("GOHEX2ASCII_CREDENTIALS", flagCredentials)
+	}
+	if flagDecoder != "" && flagFormat == "" {
+		flagFormat = flagDecoder
+	}
+
+	return nil
+}
+
+func setIfUnchanged(flags *pflag.FlagSet, name string, dst *string, fileValue string) {
+	if fileValue != "" && !flags.Changed(name) {
+		*dst = fileValue
+	}
+}
+
+// Execute runs the root cobra command with ctx threaded through so RunE
+// handlers (and the interactive wizard) see signal cancellation.
+func Execute(ctx context.Context) error {
+	return rootCmd.ExecuteContext(ctx)
+}
+
+func runConvert(ctx context.Context) error {
+	if flagSrc == "" || flagDst == "" {
+		return fmt.Errorf("--src and --dst are required")
+	}
+
+	if err := os.MkdirAll(flagDst, 0755); err != nil {
+		return fmt.Errorf("error creating destination folder: %v", err)
+	}
+
+	processFiles(ctx, flagSrc, flagDst, LocalFolder, nil, flagWorkers, flagFormat)
+	return nil
+}
+
+func runSheets(ctx context.Context) error {
+	if flagSrc == "" {
+		return fmt.Errorf("--src is required")
+	}
+
+	googleConfig, err := setupSheetsAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	googleConfig.spreadsheetId = flagSpreadsheetID
+	googleConfig.columnDelimiter = flagDelimiter
+
+	processFiles(ctx, flagSrc, "", GoogleSheets, googleConfig, flagWorkers, flagFormat)
+	return nil
+}
+
+func runDrive(ctx context.Context) error {
+	if flagSrc == "" {
+		return fmt.Errorf("--src is required")
+	}
+
+	googleConfig, err := setupDriveAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	folderID, err := ensureDriveFolder(googleConfig, flagDriveFolderID)
+	if err != nil {
+		return fmt.Errorf("error resolving destination Drive folder: %v", err)
+	}
+	googleConfig.driveFolderId = folderID
+
+	processFiles(ctx, flagSrc, "", GoogleDrive, googleConfig, flagWorkers, flagFormat)
+	return nil
+}
+
+func setupSheetsAuth(ctx context.Context) (*GoogleConfig, error) {
+	switch flagAuth {
+	case "api-key":
+		if flagAPIKey == "" {
+			return nil, fmt.Errorf("--api-key is required for --auth=api-key")
+		}
+		return setupGoogleSheetsWithApiKey(flagAPIKey)
+	case "oauth":
+		if flagCredentials == "" {
+			return nil, fmt.Errorf("--credentials is required for --auth=oauth")
+		}
+		return setupGoogleSheetsWithOAuth(ctx, flagCredentials)
+	case "service-account":
+		if flagCredentials == "" {
+			return nil, fmt.Errorf("--credentials is required for --auth=service-account")
+		}
+		return setupGoogleSheetsWithCredentials(flagCredentials)
+	default:
+		return nil, fmt.Errorf("unknown --auth method %q", flagAuth)
+	}
+}
+
+func setupDriveAuth(ctx context.Context) (*GoogleConfig, error) {
+	switch flagAuth {
+	case "oauth":
+		if flagCredentials == "" {
+			return nil, fmt.Errorf("--credentials is required for --auth=oauth")
+		}
+		return setupDriveWithOAuth(ctx, flagCredentials)
+	case "service-account":
+		if flagCredentials == "" {
+			return nil, fmt.Errorf("--credentials is required for --auth=service-account")
+		}
+		return setupDriveWithCredentials(flagCredentials)
+	default:
+		return nil, fmt.Errorf("--auth must be oauth or service-account for Drive export")
+	}
+}
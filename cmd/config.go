@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// fileConfig mirrors the CLI flags so a --config file can supply the same
+// values as scripting a full flag invocation would.
+type fileConfig struct {
+	Src           string `yaml:"src"`
+	Dst           string `yaml:"dst"`
+	SpreadsheetID string `yaml:"spreadsheet_id"`
+	DriveFolderID string `yaml:"drive_folder_id"`
+	Credentials   string `yaml:"credentials"`
+	APIKey        string `yaml:"api_key"`
+	Auth          string `yaml:"auth"`
+	Workers       int    `yaml:"workers"`
+	Format        string `yaml:"format"`
+	Delimiter     string `yaml:"delimiter"`
+}
+
+func loadFileConfig(path string) (*fileConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %v", err)
+	}
+
+	cfg := &fileConfig{}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse config file: %v", err)
+	}
+
+	return cfg, nil
+}
+
+// envOrDefault returns the named environment variable, or def if it isn't
+// set.
+func envOrDefault(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
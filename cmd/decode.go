@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Decoder turns an encoded source file into its raw decoded bytes. hexDecoder
+// covers the original "whole file is an ASCII hex dump" behavior; the other
+// implementations let the same local/Sheets/Drive exporters consume base64,
+// raw binary, and Intel HEX sources too.
+type Decoder interface {
+	Decode(r io.Reader) ([]byte, error)
+}
+
+type hexDecoder struct{}
+
+func (hexDecoder) Decode(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ascii, err := HexToAscii(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(ascii), nil
+}
+
+type base64Decoder struct{}
+
+func (base64Decoder) Decode(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaned := strings.Map(func(c rune) rune {
+		if c == '\n' || c == '\r' || c == ' ' {
+			return -1
+		}
+		return c
+	}, string(data))
+
+	return base64.StdEncoding.DecodeString(cleaned)
+}
+
+type binaryDecoder struct{}
+
+func (binaryDecoder) Decode(r io.Reader) ([]byte, error) {
+	return ioutil.ReadAll(r)
+}
+
+// intelHexDecoder parses Intel HEX records of the form
+// :llaaaatt[dd...]cc, verifies the checksum on each line, and assembles the
+// data-record payloads at their target addresses. An EOF record (01) ends
+// parsing and an extended linear address record (04) sets the upper 16 bits
+// of the address; other non-data record types are ignored.
+type intelHexDecoder struct{}
+
+func (intelHexDecoder) Decode(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	baseAddr := 0
+
+	for lineNum, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, ":") {
+			return nil, fmt.Errorf("line %d: missing ':' prefix", lineNum+1)
+		}
+
+		raw, err := hex.DecodeString(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum+1, err)
+		}
+		if len(raw) < 5 {
+			return nil, fmt.Errorf("line %d: record too short", lineNum+1)
+		}
+
+		byteCount := int(raw[0])
+		address := int(raw[1])<<8 | int(raw[2])
+		recordType := raw[3]
+
+		if len(raw) < 5+byteCount {
+			return nil, fmt.Errorf("line %d: declared byte count exceeds record length", lineNum+1)
+		}
+		payload := raw[4 : 4+byteCount]
+
+		var sum byte
+		for _, b := range raw[:5+byteCount] {
+			sum += b
+		}
+		if sum != 0 {
+			return nil, fmt.Errorf("line %d: checksum mismatch", lineNum+1)
+		}
+
+		switch recordType {
+		case 0x00: // data record
+			end := baseAddr + address + byteCount
+			if end > len(out) {
+				grown := make([]byte, end)
+				copy(grown, out)
+				out = grown
+			}
+			copy(out[baseAddr+address:end], payload)
+		case 0x01: // end of file
+			return out, nil
+		case 0x04: // extended linear address
+			if len(payload) >= 2 {
+				baseAddr = (int(payload[0])<<8 | int(payload[1])) << 16
+			}
+		default:
+			// start address / extended segment address records don't
+			// affect the decoded byte stream.
+		}
+	}
+
+	return out, nil
+}
+
+// decoderFormat identifies which Decoder to use for a source file.
+type decoderFormat string
+
+const (
+	formatHex      decoderFormat = "hex"
+	formatBase64   decoderFormat = "base64"
+	formatBinary   decoderFormat = "binary"
+	formatIntelHex decoderFormat = "intelhex"
+)
+
+var extensionFormats = map[string]decoderFormat{
+	".hex":  formatHex,
+	".b64":  formatBase64,
+	".bin":  formatBinary,
+	".ihex": formatIntelHex,
+	".srec": formatIntelHex,
+}
+
+// detectFormat maps a source file's extension to a decoderFormat, defaulting
+// to formatHex (the tool's original behavior) for unrecognized extensions.
+func detectFormat(name string) decoderFormat {
+	if f, ok := extensionFormats[strings.ToLower(filepath.Ext(name))]; ok {
+		return f
+	}
+	return formatHex
+}
+
+// decoderFor resolves override (if non-empty) or the file's detected format
+// to a Decoder instance.
+func decoderFor(name string, override string) (Decoder, error) {
+	format := detectFormat(name)
+	if override != "" {
+		format = decoderFormat(strings.ToLower(override))
+	}
+
+	switch format {
+	case formatHex:
+		return hexDecoder{}, nil
+	case formatBase64:
+		return base64Decoder{}, nil
+	case formatBinary:
+		return binaryDecoder{}, nil
+	case formatIntelHex:
+		return intelHexDecoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown decoder format %q", format)
+	}
+}
+
+// decodeSourceFile reads srcPath and decodes it with the decoder selected
+// for its extension (or override, if set), returning the decoded bytes as a
+// string ready for the local/Sheets/Drive exporters.
+func decodeSourceFile(srcPath, overrideFormat string) (string, error) {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	decoder, err := decoderFor(srcPath, overrideFormat)
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := decoder.Decode(f)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// setupDriveWithCredentials authenticates the Drive backend with a service
+// account, mirroring setupGoogleSheetsWithCredentials.
+func setupDriveWithCredentials(credentialsPath string) (*GoogleConfig, error) {
+	ctx := context.Background()
+
+	b, err := ioutil.ReadFile(credentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file: %v", err)
+	}
+
+	config, err := google.JWTConfigFromJSON(b, drive.DriveScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse credentials: %v", err)
+	}
+
+	client := config.Client(ctx)
+
+	srv, err := drive.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create drive service: %v", err)
+	}
+
+	return &GoogleConfig{
+		driveService: srv,
+		useApiKey:    false,
+	}, nil
+}
+
+// ensureDriveFolder returns folderID unchanged if set, otherwise creates a
+// new Drive folder to hold this run's exports and returns its ID.
+func ensureDriveFolder(config *GoogleConfig, folderID string) (string, error) {
+	if folderID != "" {
+		return folderID, nil
+	}
+
+	folder := &drive.File{
+		Name:     "Hex to ASCII Conversion",
+		MimeType: "application/vnd.google-apps.folder",
+	}
+
+	resp, err := config.driveService.Files.Create(folder).Do()
+	if err != nil {
+		return "", fmt.Errorf("unable to create drive folder: %v", err)
+	}
+
+	fmt.Printf("Created new Drive folder with ID: %s\n", resp.Id)
+	return resp.Id, nil
+}
+
+// uploadFileToDrive converts srcPath to ASCII and uploads it into the
+// configured Drive folder as a resumable text/plain upload.
+func uploadFileToDrive(config *GoogleConfig, srcPath, fileName, decoderOverride string) error {
+	asciiStr, err := decodeSourceFile(srcPath, decoderOverride)
+	if err != nil {
+		return err
+	}
+
+	dstName := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + ".txt"
+
+	file := &drive.File{
+		Name:    dstName,
+		Parents: []string{config.driveFolderId},
+	}
+
+	_, err = config.driveService.Files.Create(file).
+		Media(strings.NewReader(asciiStr), googleapi.ContentType("text/plain")).
+		Do()
+	if err != nil {
+		return fmt.Errorf("unable to upload file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// runInteractive drives the original prompt-based wizard. It's kept as an
+// opt-in mode (--interactive) for first-time users who don't yet have flags
+// or a config file set up; everything it does can also be done with
+// `convert`/`sheets`/`drive` flags for scripted or CI use.
+func runInteractive(ctx context.Context) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter source folder path containing hex files: ")
+	srcFolder, _ := reader.ReadString('\n')
+	srcFolder = strings.TrimSpace(srcFolder)
+
+	fmt.Println("\nChoose export option:")
+	fmt.Println("1. Export to local folder")
+	fmt.Println("2. Export to Google Sheets")
+	fmt.Println("3. Export to Google Drive")
+
+	var choice string
+	fmt.Print("Enter your choice (1, 2 or 3): ")
+	choice, _ = reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+
+	var exportOption ExportOption
+	var dstFolder string
+	var googleConfig *GoogleConfig
+
+	switch choice {
+	case "1":
+		exportOption = LocalFolder
+		fmt.Print("Enter destination folder path for ASCII files: ")
+		dstFolder, _ = reader.ReadString('\n')
+		dstFolder = strings.TrimSpace(dstFolder)
+		if err := os.MkdirAll(dstFolder, 0755); err != nil {
+			return fmt.Errorf("error creating destination folder: %v", err)
+		}
+	case "2":
+		exportOption = GoogleSheets
+		fmt.Println("\nChoose authentication method:")
+		fmt.Println("1. API Key")
+		fmt.Println("2. Service Account Credentials")
+		fmt.Println("3. Sign in with Google account (OAuth)")
+
+		var authChoice string
+		fmt.Print("Enter your choice (1, 2 or 3): ")
+		authChoice, _ = reader.ReadString('\n')
+		authChoice = strings.TrimSpace(authChoice)
+
+		var err error
+		switch authChoice {
+		case "1":
+			fmt.Print("Enter Google Sheets API Key: ")
+			apiKey, _ := reader.ReadString('\n')
+			apiKey = strings.TrimSpace(apiKey)
+			googleConfig, err = setupGoogleSheetsWithApiKey(apiKey)
+		case "2":
+			fmt.Print("Enter path to Google credentials JSON file: ")
+			credPath, _ := reader.ReadString('\n')
+			credPath = strings.TrimSpace(credPath)
+			googleConfig, err = setupGoogleSheetsWithCredentials(credPath)
+		case "3":
+			fmt.Print("Enter path to OAuth client_secret.json: ")
+			credPath, _ := reader.ReadString('\n')
+			credPath = strings.TrimSpace(credPath)
+			googleConfig, err = setupGoogleSheetsWithOAuth(ctx, credPath)
+		default:
+			return fmt.Errorf("invalid authentication choice")
+		}
+
+		if err != nil {
+			return fmt.Errorf("error setting up Google Sheets: %v", err)
+		}
+
+		fmt.Print("Enter spreadsheet ID (or leave empty to create new): ")
+		googleConfig.spreadsheetId, _ = reader.ReadString('\n')
+		googleConfig.spreadsheetId = strings.TrimSpace(googleConfig.spreadsheetId)
+
+		fmt.Print("Enter column delimiter (leave empty for tab): ")
+		googleConfig.columnDelimiter, _ = reader.ReadString('\n')
+		googleConfig.columnDelimiter = strings.TrimRight(googleConfig.columnDelimiter, "\n\r")
+		if googleConfig.columnDelimiter == "" {
+			googleConfig.columnDelimiter = "\t"
+		}
+	case "3":
+		exportOption = GoogleDrive
+		fmt.Println("\nChoose authentication method:")
+		fmt.Println("1. Service Account Credentials")
+		fmt.Println("2. Sign in with Google account (OAuth)")
+
+		var authChoice string
+		fmt.Print("Enter your choice (1 or 2): ")
+		authChoice, _ = reader.ReadString('\n')
+		authChoice = strings.TrimSpace(authChoice)
+
+		var err error
+		switch authChoice {
+		case "1":
+			fmt.Print("Enter path to Google credentials JSON file: ")
+			credPath, _ := reader.ReadString('\n')
+			credPath = strings.TrimSpace(credPath)
+			googleConfig, err = setupDriveWithCredentials(credPath)
+		case "2":
+			fmt.Print("Enter path to OAuth client_secret.json: ")
+			credPath, _ := reader.ReadString('\n')
+			credPath = strings.TrimSpace(credPath)
+			googleConfig, err = setupDriveWithOAuth(ctx, credPath)
+		default:
+			return fmt.Errorf("invalid authentication choice")
+		}
+
+		if err != nil {
+			return fmt.Errorf("error setting up Google Drive: %v", err)
+		}
+
+		fmt.Print("Enter destination Drive folder ID (or leave empty to create new): ")
+		folderID, _ := reader.ReadString('\n')
+		folderID = strings.TrimSpace(folderID)
+
+		folderID, err = ensureDriveFolder(googleConfig, folderID)
+		if err != nil {
+			return fmt.Errorf("error resolving destination Drive folder: %v", err)
+		}
+		googleConfig.driveFolderId = folderID
+	default:
+		return fmt.Errorf("invalid choice")
+	}
+
+	fmt.Printf("Enter number of parallel workers (leave empty for %d): ", runtime.NumCPU())
+	workersInput, _ := reader.ReadString('\n')
+	workersInput = strings.TrimSpace(workersInput)
+
+	workers := runtime.NumCPU()
+	if workersInput != "" {
+		if n, err := strconv.Atoi(workersInput); err == nil && n > 0 {
+			workers = n
+		}
+	}
+
+	processFiles(ctx, srcFolder, dstFolder, exportOption, googleConfig, workers, "")
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fileJob struct {
+	srcPath string
+	name    string
+}
+
+type fileResult struct {
+	name string
+	err  error
+}
+
+// processFiles fans the directory's files out across workers parallel
+// workers, each doing read+decode+export for its own jobs. Results stream
+// back over a channel so progress can be reported as they complete, and
+// ctx.Done() is checked both when dispatching and inside each worker so an
+// interrupt aborts promptly instead of draining the whole queue first.
+func processFiles(ctx context.Context, srcFolder, dstFolder string, exportOption ExportOption, googleConfig *GoogleConfig, workers int, decoderOverride string) {
+	files, err := ioutil.ReadDir(srcFolder)
+	if err != nil {
+		fmt.Printf("Error reading source folder: %v\n", err)
+		return
+	}
+
+	var jobs []fileJob
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		jobs = append(jobs, fileJob{
+			srcPath: filepath.Join(srcFolder, file.Name()),
+			name:    file.Name(),
+		})
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println("No files to process.")
+		return
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobChan := make(chan fileJob)
+	resultChan := make(chan fileResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobChan {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				err := processOneFile(job, dstFolder, exportOption, googleConfig, decoderOverride)
+
+				select {
+				case resultChan <- fileResult{name: job.name, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobChan)
+		for _, job := range jobs {
+			select {
+			case jobChan <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	reporter := newProgressReporter(len(jobs))
+	for result := range resultChan {
+		if result.err != nil {
+			fmt.Printf("\nError processing %s: %v\n", result.name, result.err)
+		}
+		reporter.increment()
+	}
+	reporter.done()
+
+	if exportOption == GoogleSheets && googleConfig != nil {
+		if err := flushSheetWrites(googleConfig); err != nil {
+			fmt.Printf("\nError flushing pending Sheets writes: %v\n", err)
+		}
+	}
+
+	if ctx.Err() != nil {
+		fmt.Println("Processing interrupted.")
+	}
+}
+
+func processOneFile(job fileJob, dstFolder string, exportOption ExportOption, googleConfig *GoogleConfig, decoderOverride string) error {
+	switch exportOption {
+	case LocalFolder:
+		dstPath := filepath.Join(dstFolder, strings.TrimSuffix(job.name, filepath.Ext(job.name))+".txt")
+		if fileExists(dstPath) {
+			return nil
+		}
+		return convertFile(job.srcPath, dstPath, decoderOverride)
+	case GoogleSheets:
+		return exportToGoogleSheets(job.srcPath, job.name, googleConfig, decoderOverride)
+	case GoogleDrive:
+		return uploadFileToDrive(googleConfig, job.srcPath, job.name, decoderOverride)
+	}
+
+	return fmt.Errorf("unknown export option")
+}
+
+// progressReporter prints a single overwritten status line rather than one
+// line per file, since a worker pool makes per-file prints interleave.
+type progressReporter struct {
+	total     int
+	processed int
+	start     time.Time
+	mu        sync.Mutex
+}
+
+func newProgressReporter(total int) *progressReporter {
+	return &progressReporter{total: total, start: time.Now()}
+}
+
+func (p *progressReporter) increment() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.processed++
+	elapsed := time.Since(p.start).Seconds()
+	rate := float64(p.processed) / elapsed
+	var eta time.Duration
+	if rate > 0 {
+		eta = time.Duration(float64(p.total-p.processed)/rate) * time.Second
+	}
+
+	fmt.Printf("\rProcessed %d/%d (%.1f files/sec, ETA %s)   ", p.processed, p.total, rate, eta.Round(time.Second))
+}
+
+func (p *progressReporter) done() {
+	fmt.Println()
+}
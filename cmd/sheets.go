@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// sheetTitleIllegalChars are the characters Sheets refuses in a tab name.
+const sheetTitleIllegalChars = "[]*?/\\:"
+
+const indexSheetTitle = "Index"
+
+func setupGoogleSheetsWithApiKey(apiKey string) (*GoogleConfig, error) {
+	ctx := context.Background()
+	srv, err := sheets.NewService(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create sheets service: %v", err)
+	}
+
+	return &GoogleConfig{
+		sheetsService: srv,
+		useApiKey:     true,
+	}, nil
+}
+
+func setupGoogleSheetsWithCredentials(credentialsPath string) (*GoogleConfig, error) {
+	ctx := context.Background()
+
+	b, err := ioutil.ReadFile(credentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file: %v", err)
+	}
+
+	config, err := google.JWTConfigFromJSON(b, sheets.SpreadsheetsScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse credentials: %v", err)
+	}
+
+	client := config.Client(ctx)
+
+	srv, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create sheets service: %v", err)
+	}
+
+	return &GoogleConfig{
+		sheetsService: srv,
+		useApiKey:     false,
+	}, nil
+}
+
+// sanitizeSheetTitle strips characters Sheets disallows in a tab name and
+// truncates to Sheets' 100-char limit.
+func sanitizeSheetTitle(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(sheetTitleIllegalChars, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	title := b.String()
+	if runes := []rune(title); len(runes) > 100 {
+		title = string(runes[:100])
+	}
+
+	return title
+}
+
+// splitColumns splits line on delimiter with CSV-style quote handling: a
+// field wrapped in double quotes may contain the delimiter, and a doubled
+// quote ("") inside a quoted field is an escaped literal quote.
+func splitColumns(line, delimiter string) []string {
+	var fields []string
+	var field strings.Builder
+	inQuotes := false
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		if inQuotes {
+			if c == '"' {
+				if i+1 < len(runes) && runes[i+1] == '"' {
+					field.WriteRune('"')
+					i++
+					continue
+				}
+				inQuotes = false
+				continue
+			}
+			field.WriteRune(c)
+			continue
+		}
+
+		if c == '"' && field.Len() == 0 {
+			inQuotes = true
+			continue
+		}
+
+		if strings.HasPrefix(string(runes[i:]), delimiter) {
+			fields = append(fields, field.String())
+			field.Reset()
+			i += len(delimiter) - 1
+			continue
+		}
+
+		field.WriteRune(c)
+	}
+	fields = append(fields, field.String())
+
+	return fields
+}
+
+// valuesToCellData converts a row of loosely-typed values into Sheets
+// CellData, dispatching on the Go type so numeric hex-decoded payloads land
+// in NumberValue rather than being stringified.
+func valuesToCellData(row []interface{}) []*sheets.CellData {
+	cells := make([]*sheets.CellData, len(row))
+
+	for i, v := range row {
+		ev := &sheets.ExtendedValue{}
+
+		switch val := v.(type) {
+		case string:
+			if f, err := strconv.ParseFloat(val, 64); err == nil && val != "" {
+				ev.NumberValue = &f
+			} else {
+				s := val
+				ev.StringValue = &s
+			}
+		case float64:
+			ev.NumberValue = &val
+		case int64:
+			f := float64(val)
+			ev.NumberValue = &f
+		case bool:
+			ev.BoolValue = &val
+		default:
+			s := fmt.Sprintf("%v", val)
+			ev.StringValue = &s
+		}
+
+		cells[i] = &sheets.CellData{UserEnteredValue: ev}
+	}
+
+	return cells
+}
+
+// ensureIndexSheet creates the "Index" tab with its header row the first
+// time it's needed; subsequent calls are a no-op.
+func ensureIndexSheet(config *GoogleConfig) error {
+	if config.indexSheetReady {
+		return nil
+	}
+
+	req := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				AddSheet: &sheets.AddSheetRequest{
+					Properties: &sheets.SheetProperties{Title: indexSheetTitle},
+				},
+			},
+		},
+	}
+
+	err := withRetry(func() error {
+		_, err := config.sheetsService.Spreadsheets.BatchUpdate(config.spreadsheetId, req).Do()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create index sheet: %v", err)
+	}
+
+	header := &sheets.ValueRange{
+		Values: [][]interface{}{{"Filename", "Tab", "Rows", "Link"}},
+	}
+	err = withRetry(func() error {
+		_, err := config.sheetsService.Spreadsheets.Values.Update(
+			config.spreadsheetId,
+			indexSheetTitle+"!A1",
+			header,
+		).ValueInputOption("RAW").Do()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write index header: %v", err)
+	}
+
+	config.indexSheetReady = true
+	return nil
+}
+
+// appendIndexRow records one converted file's entry in the Index tab,
+// including a hyperlink formula to jump straight to its tab.
+func appendIndexRow(config *GoogleConfig, fileName, tabTitle string, rowCount int, sheetID int64) error {
+	link := fmt.Sprintf(`=HYPERLINK("#gid=%d", "open")`, sheetID)
+
+	values := &sheets.ValueRange{
+		Values: [][]interface{}{{fileName, tabTitle, rowCount, link}},
+	}
+
+	err := withRetry(func() error {
+		_, err := config.sheetsService.Spreadsheets.Values.Append(
+			config.spreadsheetId,
+			indexSheetTitle+"!A1",
+			values,
+		).ValueInputOption("USER_ENTERED").Do()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to append index row: %v", err)
+	}
+
+	return nil
+}
+
+// exportToGoogleSheets converts srcPath and writes it into its own tab,
+// splitting each line into columns on config.columnDelimiter, then records
+// the tab in the shared Index sheet.
+func exportToGoogleSheets(srcPath, fileName string, config *GoogleConfig, decoderOverride string) error {
+	asciiStr, err := decodeSourceFile(srcPath, decoderOverride)
+	if err != nil {
+		return err
+	}
+
+	// Creating the spreadsheet and its Index tab happens once per run, but
+	// exportToGoogleSheets is called concurrently by the worker pool, so
+	// guard the lazy setup with a mutex rather than racing on spreadsheetId.
+	config.setupMu.Lock()
+	if config.spreadsheetId == "" {
+		if config.useApiKey {
+			config.setupMu.Unlock()
+			return fmt.Errorf("cannot create new spreadsheet with API key authentication. Please provide an existing spreadsheet ID")
+		}
+
+		spreadsheet := &sheets.Spreadsheet{
+			Properties: &sheets.SpreadsheetProperties{
+				Title: "Hex to ASCII Conversion",
+			},
+		}
+
+		resp, err := config.sheetsService.Spreadsheets.Create(spreadsheet).Do()
+		if err != nil {
+			config.setupMu.Unlock()
+			return fmt.Errorf("unable to create spreadsheet: %v", err)
+		}
+		config.spreadsheetId = resp.SpreadsheetId
+		fmt.Printf("Created new spreadsheet with ID: %s\n", config.spreadsheetId)
+	}
+
+	err = ensureIndexSheet(config)
+	config.setupMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	delimiter := config.columnDelimiter
+	if delimiter == "" {
+		delimiter = "\t"
+	}
+
+	lines := strings.Split(strings.TrimRight(asciiStr, "\n"), "\n")
+	rows := make([][]interface{}, len(lines))
+	for i, line := range lines {
+		cols := splitColumns(line, delimiter)
+		row := make([]interface{}, len(cols))
+		for j, c := range cols {
+			row[j] = c
+		}
+		rows[i] = row
+	}
+
+	tabTitle := sanitizeSheetTitle(fileName)
+
+	var addSheetResp *sheets.BatchUpdateSpreadsheetResponse
+	err = withRetry(func() error {
+		var err error
+		addSheetResp, err = config.sheetsService.Spreadsheets.BatchUpdate(config.spreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{
+					AddSheet: &sheets.AddSheetRequest{
+						Properties: &sheets.SheetProperties{Title: tabTitle},
+					},
+				},
+			},
+		}).Do()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create tab for %s: %v", fileName, err)
+	}
+
+	sheetID := addSheetResp.Replies[0].AddSheet.Properties.SheetId
+
+	if err := enqueueSheetWrite(config, newUpdateCellsRequest(sheetID, rows)); err != nil {
+		return fmt.Errorf("unable to write rows for %s: %v", fileName, err)
+	}
+
+	return appendIndexRow(config, fileName, tabTitle, len(rows), sheetID)
+}
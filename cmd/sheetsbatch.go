@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// Sheets write-quota constants: flush pending per-tab writes once N files
+// have queued up or T seconds have passed, whichever comes first, to stay
+// under Sheets' per-minute write quota.
+const (
+	sheetsBatchSize     = 5
+	sheetsBatchInterval = 5 * time.Second
+	maxRetries          = 5
+)
+
+// newUpdateCellsRequest builds an UpdateCells request that writes rows
+// starting at the top-left of sheetID, going through valuesToCellData so
+// numeric hex-decoded payloads land in NumberValue rather than being
+// stringified.
+func newUpdateCellsRequest(sheetID int64, rows [][]interface{}) *sheets.Request {
+	rowData := make([]*sheets.RowData, len(rows))
+	for i, row := range rows {
+		rowData[i] = &sheets.RowData{Values: valuesToCellData(row)}
+	}
+
+	return &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start:  &sheets.GridCoordinate{SheetId: sheetID, RowIndex: 0, ColumnIndex: 0},
+			Rows:   rowData,
+			Fields: "userEnteredValue",
+		},
+	}
+}
+
+// enqueueSheetWrite queues a tab's UpdateCells request and flushes the
+// buffer once it crosses the size or time threshold.
+func enqueueSheetWrite(config *GoogleConfig, req *sheets.Request) error {
+	config.writeMu.Lock()
+	if config.pendingWrites == nil {
+		config.lastFlush = time.Now()
+	}
+	config.pendingWrites = append(config.pendingWrites, req)
+
+	shouldFlush := len(config.pendingWrites) >= sheetsBatchSize || time.Since(config.lastFlush) >= sheetsBatchInterval
+	config.writeMu.Unlock()
+
+	if shouldFlush {
+		return flushSheetWrites(config)
+	}
+
+	return nil
+}
+
+// flushSheetWrites sends any buffered per-tab UpdateCells requests in a
+// single Spreadsheets.BatchUpdate call and clears the buffer.
+func flushSheetWrites(config *GoogleConfig) error {
+	config.writeMu.Lock()
+	pending := config.pendingWrites
+	config.pendingWrites = nil
+	config.lastFlush = time.Now()
+	config.writeMu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	req := &sheets.BatchUpdateSpreadsheetRequest{Requests: pending}
+
+	return withRetry(func() error {
+		_, err := config.sheetsService.Spreadsheets.BatchUpdate(config.spreadsheetId, req).Do()
+		return err
+	})
+}
+
+// withRetry retries fn with exponential backoff when the Google API
+// reports a rate-limit (429) or transient (503) error.
+func withRetry(fn func() error) error {
+	backoff := 500 * time.Millisecond
+
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		if apiErr, ok := err.(*googleapi.Error); ok && (apiErr.Code == 429 || apiErr.Code == 503) {
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+
+		return err
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %v", maxRetries, err)
+}